@@ -8,6 +8,8 @@ import (
 	"github.com/grafana/grafana-app-sdk/operator"
 	"github.com/grafana/grafana-app-sdk/resource"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	issuev1 "github.com/grafana/issue-tracker-project/pkg/generated/issue/v1"
 )
@@ -26,8 +28,9 @@ func (s *IssueWatcher) Add(ctx context.Context, rObj resource.Object) error {
 	defer span.End()
 	object, ok := rObj.(*issuev1.Issue)
 	if !ok {
-		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s)",
-			rObj.GetStaticMetadata().Name, rObj.GetStaticMetadata().Namespace, rObj.GetStaticMetadata().Kind)
+		// The object will never be the right type on a retry, so don't let the workqueue re-attempt it.
+		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s): %w",
+			rObj.GetStaticMetadata().Name, rObj.GetStaticMetadata().Namespace, rObj.GetStaticMetadata().Kind, operator.ErrTerminal)
 	}
 
 	// TODO
@@ -37,22 +40,51 @@ func (s *IssueWatcher) Add(ctx context.Context, rObj resource.Object) error {
 
 // Update handles update events for issuev1.Issue resources.
 func (s *IssueWatcher) Update(ctx context.Context, rOld resource.Object, rNew resource.Object) error {
-	ctx, span := otel.GetTracerProvider().Tracer("watcher").Start(ctx, "watcher-update")
+	// ctx is already a child of rNew's traceparent (extracted by the operator's event dispatcher). rOld may carry a
+	// different traceparent, e.g. when the update is several writes removed from the one that produced rNew's
+	// context, so link its span in too rather than silently losing it.
+	var spanOpts []trace.SpanStartOption
+	oldTraceparent, newTraceparent := rOld.GetAnnotations()[resource.AnnotationTraceparent], rNew.GetAnnotations()[resource.AnnotationTraceparent]
+	if oldTraceparent != "" && oldTraceparent != newTraceparent {
+		oldCtx := otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier{"traceparent": oldTraceparent})
+		if sc := trace.SpanContextFromContext(oldCtx); sc.IsValid() {
+			spanOpts = append(spanOpts, trace.WithLinks(trace.Link{SpanContext: sc}))
+		}
+	}
+
+	ctx, span := otel.GetTracerProvider().Tracer("watcher").Start(ctx, "watcher-update", spanOpts...)
 	defer span.End()
 	oldObject, ok := rOld.(*issuev1.Issue)
 	if !ok {
-		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s)",
-			rOld.GetStaticMetadata().Name, rOld.GetStaticMetadata().Namespace, rOld.GetStaticMetadata().Kind)
+		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s): %w",
+			rOld.GetStaticMetadata().Name, rOld.GetStaticMetadata().Namespace, rOld.GetStaticMetadata().Kind, operator.ErrTerminal)
 	}
 
-	_, ok = rNew.(*issuev1.Issue)
+	newObject, ok := rNew.(*issuev1.Issue)
 	if !ok {
-		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s)",
-			rNew.GetStaticMetadata().Name, rNew.GetStaticMetadata().Namespace, rNew.GetStaticMetadata().Kind)
+		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s): %w",
+			rNew.GetStaticMetadata().Name, rNew.GetStaticMetadata().Namespace, rNew.GetStaticMetadata().Kind, operator.ErrTerminal)
 	}
 
-	// TODO
-	logging.FromContext(ctx).Debug("Updated resource", "name", oldObject.GetStaticMetadata().Identifier().Name)
+	// Find the top-level fields that changed between rOld and rNew, using the last user-applied configuration to
+	// detect removed fields. Changes made by the server or another controller (a status write, defaulting) are
+	// reported as well.
+	_, changedFields, err := resource.ThreeWayDiff(oldObject, newObject, oldObject.GetAnnotations()[resource.AnnotationLastAppliedConfig])
+	if err != nil {
+		return fmt.Errorf("computing diff for resource update (name=%s): %w", oldObject.GetStaticMetadata().Identifier().Name, err)
+	}
+	diff := resource.NewDiff(changedFields)
+
+	if diff.Changed("spec") {
+		// onSpecChanged
+		// TODO
+	}
+	if diff.Changed("status") {
+		// onStatusChanged
+		// TODO
+	}
+
+	logging.FromContext(ctx).Debug("Updated resource", "name", oldObject.GetStaticMetadata().Identifier().Name, "changedFields", changedFields)
 	return nil
 }
 
@@ -62,8 +94,8 @@ func (s *IssueWatcher) Delete(ctx context.Context, rObj resource.Object) error {
 	defer span.End()
 	object, ok := rObj.(*issuev1.Issue)
 	if !ok {
-		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s)",
-			rObj.GetStaticMetadata().Name, rObj.GetStaticMetadata().Namespace, rObj.GetStaticMetadata().Kind)
+		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s): %w",
+			rObj.GetStaticMetadata().Name, rObj.GetStaticMetadata().Namespace, rObj.GetStaticMetadata().Kind, operator.ErrTerminal)
 	}
 
 	// TODO
@@ -78,11 +110,29 @@ func (s *IssueWatcher) Sync(ctx context.Context, rObj resource.Object) error {
 	defer span.End()
 	object, ok := rObj.(*issuev1.Issue)
 	if !ok {
-		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s)",
-			rObj.GetStaticMetadata().Name, rObj.GetStaticMetadata().Namespace, rObj.GetStaticMetadata().Kind)
+		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s): %w",
+			rObj.GetStaticMetadata().Name, rObj.GetStaticMetadata().Namespace, rObj.GetStaticMetadata().Kind, operator.ErrTerminal)
 	}
 
 	// TODO
 	logging.FromContext(ctx).Debug("Possible resource update", "name", object.GetStaticMetadata().Identifier().Name)
 	return nil
 }
+
+// Reconcile is not a standard resource.Watcher function, but is used when wrapping this watcher in an
+// operator.OpinionatedWatcher with a ReconcilePeriod configured. Unlike Sync, which fires once after an outage,
+// Reconcile fires on a steady cadence for every known object, so it can be used to drive state toward a desired
+// condition independent of any Add/Update/Delete event (status refresh, external system polling, TTL expiry).
+func (s *IssueWatcher) Reconcile(ctx context.Context, rObj resource.Object) error {
+	ctx, span := otel.GetTracerProvider().Tracer("watcher").Start(ctx, "watcher-reconcile")
+	defer span.End()
+	object, ok := rObj.(*issuev1.Issue)
+	if !ok {
+		return fmt.Errorf("provided object is not of type *issuev1.Issue (name=%s, namespace=%s, kind=%s): %w",
+			rObj.GetStaticMetadata().Name, rObj.GetStaticMetadata().Namespace, rObj.GetStaticMetadata().Kind, operator.ErrTerminal)
+	}
+
+	// TODO
+	logging.FromContext(ctx).Debug("Reconciling resource", "name", object.GetStaticMetadata().Identifier().Name)
+	return nil
+}
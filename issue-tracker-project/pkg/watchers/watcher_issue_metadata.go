@@ -0,0 +1,64 @@
+package watchers
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-app-sdk/logging"
+	"github.com/grafana/grafana-app-sdk/operator"
+	"github.com/grafana/grafana-app-sdk/resource"
+	"go.opentelemetry.io/otel"
+)
+
+var _ operator.MetadataWatcher = &MetadataIssueWatcher{}
+
+// MetadataIssueWatcher is the metadata-only counterpart to IssueWatcher, generated when the watcher is configured
+// with --metadata-only. It receives a resource.PartialObject (TypeMeta + ObjectMeta + OwnerReferences only) instead
+// of a fully-deserialized issuev1.Issue, for use cases that only need identity/labels/ownership (GC, label-driven
+// routing, cross-kind fan-out) and want to avoid the cost of decoding the full spec/status.
+type MetadataIssueWatcher struct{}
+
+func NewMetadataIssueWatcher() (*MetadataIssueWatcher, error) {
+	return &MetadataIssueWatcher{}, nil
+}
+
+// Add handles add events for resource.PartialObject metadata of issuev1.Issue resources.
+func (s *MetadataIssueWatcher) Add(ctx context.Context, pObj resource.PartialObject) error {
+	ctx, span := otel.GetTracerProvider().Tracer("watcher").Start(ctx, "watcher-metadata-add")
+	defer span.End()
+
+	// TODO
+	logging.FromContext(ctx).Debug("Added resource", "name", pObj.GetName())
+	return nil
+}
+
+// Update handles update events for resource.PartialObject metadata of issuev1.Issue resources.
+func (s *MetadataIssueWatcher) Update(ctx context.Context, pOld resource.PartialObject, pNew resource.PartialObject) error {
+	ctx, span := otel.GetTracerProvider().Tracer("watcher").Start(ctx, "watcher-metadata-update")
+	defer span.End()
+
+	// TODO
+	logging.FromContext(ctx).Debug("Updated resource", "name", pOld.GetName())
+	return nil
+}
+
+// Delete handles delete events for resource.PartialObject metadata of issuev1.Issue resources.
+func (s *MetadataIssueWatcher) Delete(ctx context.Context, pObj resource.PartialObject) error {
+	ctx, span := otel.GetTracerProvider().Tracer("watcher").Start(ctx, "watcher-metadata-delete")
+	defer span.End()
+
+	// TODO
+	logging.FromContext(ctx).Debug("Deleted resource", "name", pObj.GetName())
+	return nil
+}
+
+// Sync is not a standard operator.MetadataWatcher function; it implements operator.MetadataSyncWatcher, which
+// operator.MetadataInformerDispatcher calls for every object in its initial list. It handles resources which MAY
+// have been updated during an outage period where the watcher was not able to consume events.
+func (s *MetadataIssueWatcher) Sync(ctx context.Context, pObj resource.PartialObject) error {
+	ctx, span := otel.GetTracerProvider().Tracer("watcher").Start(ctx, "watcher-metadata-sync")
+	defer span.End()
+
+	// TODO
+	logging.FromContext(ctx).Debug("Possible resource update", "name", pObj.GetName())
+	return nil
+}
@@ -0,0 +1,59 @@
+package operator
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/record"
+
+	"github.com/grafana/grafana-app-sdk/logging"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// DeadLetterSink receives events that RetryingResourceWatcher gave up on -- either because they exhausted
+// BackoffConfig.MaxAttempts, or because the wrapped ResourceWatcher returned an error wrapping ErrTerminal.
+type DeadLetterSink interface {
+	// Send is called with the event kind ("add", "update", or "delete"), the object the event was for, the last
+	// error the wrapped watcher returned, and the number of attempts made before giving up.
+	Send(ctx context.Context, kind string, obj resource.Object, lastErr error, attempts int)
+}
+
+// LoggingDeadLetterSink is the default DeadLetterSink. It logs the dropped event and, if OnDrop is set, reports it
+// through an arbitrary metrics hook. It never errors, since there is nowhere left to surface a failure about
+// failing to handle a failure.
+type LoggingDeadLetterSink struct {
+	// OnDrop, if set, is invoked after logging -- e.g. to increment a Prometheus counter. It must not block.
+	OnDrop func(kind string, obj resource.Object, attempts int)
+}
+
+// Send implements DeadLetterSink.
+func (s *LoggingDeadLetterSink) Send(ctx context.Context, kind string, obj resource.Object, lastErr error, attempts int) {
+	meta := obj.GetStaticMetadata()
+	logging.FromContext(ctx).Error("dropping event after exhausting retries", "kind", kind, "name", meta.Name,
+		"namespace", meta.Namespace, "resourceKind", meta.Kind, "attempts", attempts, "error", lastErr)
+	if s.OnDrop != nil {
+		s.OnDrop(kind, obj, attempts)
+	}
+}
+
+var _ DeadLetterSink = &LoggingDeadLetterSink{}
+
+// EventRecorderDeadLetterSink is a DeadLetterSink that emits a Kubernetes Warning event on the object itself, via
+// an EventRecorder such as the one returned by EventBroadcaster.NewRecorder in client-go, so the failure is
+// visible to `kubectl describe` and anything else already watching Events for the object.
+type EventRecorderDeadLetterSink struct {
+	Recorder record.EventRecorder
+	// Reason is the Event reason to use; defaults to "WatcherRetriesExhausted" when empty.
+	Reason string
+}
+
+// Send implements DeadLetterSink.
+func (s *EventRecorderDeadLetterSink) Send(_ context.Context, kind string, obj resource.Object, lastErr error, attempts int) {
+	reason := s.Reason
+	if reason == "" {
+		reason = "WatcherRetriesExhausted"
+	}
+	s.Recorder.Eventf(obj, "Warning", reason,
+		"giving up on %s event after %d attempts: %v", kind, attempts, lastErr)
+}
+
+var _ DeadLetterSink = &EventRecorderDeadLetterSink{}
@@ -0,0 +1,10 @@
+package operator
+
+import "errors"
+
+// ErrTerminal marks an error returned from a ResourceWatcher method as non-retryable. Wrap the underlying cause
+// with fmt.Errorf's %w verb (errors.Is must still see ErrTerminal through the chain) when an event will never
+// succeed no matter how many times it is redelivered -- for example, a payload that doesn't deserialize to the
+// type the watcher expects. RetryingResourceWatcher checks for it with errors.Is and routes the event straight to
+// the DeadLetterSink instead of requeuing it.
+var ErrTerminal = errors.New("operator: terminal error, event will not be retried")
@@ -0,0 +1,111 @@
+package operator
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// MetadataWatcher is the metadata-only counterpart to ResourceWatcher: it reacts to Add/Update/Delete events
+// carrying only a resource.PartialObject (TypeMeta + ObjectMeta, including OwnerReferences) rather than a fully
+// deserialized Object. It exists for operators that only need identity, labels, or ownership -- garbage
+// collection, label-driven routing, cross-kind fan-out -- so they don't pay the cost of decoding every object's
+// full spec/status.
+//
+// A MetadataWatcher is driven by an InformerOptions with MetadataOnly set to true, which causes the informer to
+// be built against the Kubernetes PartialObjectMetadata REST representation instead of the typed or unstructured
+// one.
+type MetadataWatcher interface {
+	Add(ctx context.Context, obj resource.PartialObject) error
+	Update(ctx context.Context, oldObj, newObj resource.PartialObject) error
+	Delete(ctx context.Context, obj resource.PartialObject) error
+}
+
+// MetadataSyncWatcher may optionally be implemented alongside MetadataWatcher, mirroring SyncWatcher.
+type MetadataSyncWatcher interface {
+	Sync(ctx context.Context, obj resource.PartialObject) error
+}
+
+// InformerOptions configures how an operator builds the informer backing a watcher.
+type InformerOptions struct {
+	// MetadataOnly, when true, builds the informer against the PartialObjectMetadata REST endpoint (the same
+	// `?includeObject=Metadata` semantics client-go's metadata.Interface uses) and dispatches events to a
+	// MetadataWatcher instead of decoding and dispatching full objects to a ResourceWatcher. This is
+	// substantially cheaper for high-cardinality kinds whose watchers never look past identity/labels/ownership.
+	MetadataOnly bool
+}
+
+// MetadataInformerLister is the minimal client the metadata informer dispatcher needs: a way to list and watch
+// PartialObjectMetadata for a kind, analogous to client-go's metadatainformer.NewFilteredMetadataInformer but
+// expressed in terms of this SDK's resource.PartialObject.
+type MetadataInformerLister interface {
+	ListPartialObjects(ctx context.Context) ([]resource.PartialObject, error)
+	WatchPartialObjects(ctx context.Context) (<-chan MetadataEvent, error)
+}
+
+// MetadataEvent is a single Add/Update/Delete notification from a MetadataInformerLister's watch.
+type MetadataEvent struct {
+	Kind string // "add", "update", or "delete"
+	Old  *resource.PartialObject
+	New  resource.PartialObject
+}
+
+// MetadataInformerDispatcher drives a MetadataWatcher from a MetadataInformerLister: it lists the initial state,
+// dispatching each object to Sync (if the watcher implements MetadataSyncWatcher), then forwards each watch event
+// to Add/Update/Delete. Events of any other kind are ignored.
+type MetadataInformerDispatcher struct {
+	Lister  MetadataInformerLister
+	Watcher MetadataWatcher
+}
+
+// Run lists the initial state and then forwards watch events until ctx is canceled (returning ctx.Err()) or the
+// watch channel closes (returning nil). The first error from the lister or from a watcher method stops Run and is
+// returned; no later events are delivered.
+func (d *MetadataInformerDispatcher) Run(ctx context.Context) error {
+	objects, err := d.Lister.ListPartialObjects(ctx)
+	if err != nil {
+		return err
+	}
+	if sync, ok := d.Watcher.(MetadataSyncWatcher); ok {
+		for _, obj := range objects {
+			if err := sync.Sync(ctx, obj); err != nil {
+				return err
+			}
+		}
+	}
+
+	events, err := d.Lister.WatchPartialObjects(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := d.dispatch(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (d *MetadataInformerDispatcher) dispatch(ctx context.Context, event MetadataEvent) error {
+	switch event.Kind {
+	case "add":
+		return d.Watcher.Add(ctx, event.New)
+	case "update":
+		var old resource.PartialObject
+		if event.Old != nil {
+			old = *event.Old
+		}
+		return d.Watcher.Update(ctx, old, event.New)
+	case "delete":
+		return d.Watcher.Delete(ctx, event.New)
+	default:
+		return nil
+	}
+}
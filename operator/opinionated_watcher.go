@@ -0,0 +1,107 @@
+package operator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// OpinionatedWatcherConfig configures the optional behavior OpinionatedWatcher layers on top of a plain
+// ResourceWatcher.
+type OpinionatedWatcherConfig struct {
+	// ReconcilePeriod, if non-zero, causes OpinionatedWatcher.Run to drive the wrapped watcher's Reconcile method
+	// (the wrapped watcher must implement PeriodicReconciler) on that cadence via a ReconcileScheduler. A zero
+	// value disables periodic reconciliation; only event-driven Add/Update/Delete/Sync fire.
+	ReconcilePeriod time.Duration
+	// ReconcileJitter is forwarded to ReconcileScheduler.Jitter.
+	ReconcileJitter float64
+	// ReconcileMetrics is forwarded to ReconcileScheduler.Metrics.
+	ReconcileMetrics ReconcileMetrics
+	// Kind labels reconcile ticks reported to ReconcileMetrics.
+	Kind string
+}
+
+// OpinionatedWatcher wraps a ResourceWatcher with the bookkeeping generated watchers are documented to expect:
+// it tracks which objects currently have an Add/Update/Delete in flight so a concurrent Reconcile tick is
+// coalesced with (skipped in favor of) the event handler rather than racing it, and, when ReconcilePeriod is
+// configured, drives the wrapped watcher's Reconcile method on that cadence.
+type OpinionatedWatcher struct {
+	Wrapped ResourceWatcher
+	Lister  ObjectLister
+	Config  OpinionatedWatcherConfig
+
+	inFlight sync.Map // resourceKey -> struct{}
+}
+
+// NewOpinionatedWatcher wraps watcher with the given lister (used only when Config.ReconcilePeriod is non-zero)
+// and config.
+func NewOpinionatedWatcher(watcher ResourceWatcher, lister ObjectLister, config OpinionatedWatcherConfig) *OpinionatedWatcher {
+	return &OpinionatedWatcher{Wrapped: watcher, Lister: lister, Config: config}
+}
+
+func (w *OpinionatedWatcher) markInFlight(obj resource.Object) func() {
+	key := keyForObject("inflight", obj)
+	w.inFlight.Store(key, struct{}{})
+	return func() { w.inFlight.Delete(key) }
+}
+
+// Add implements ResourceWatcher.
+func (w *OpinionatedWatcher) Add(ctx context.Context, obj resource.Object) error {
+	defer w.markInFlight(obj)()
+	return w.Wrapped.Add(ExtractTraceContext(ctx, obj), obj)
+}
+
+// Update implements ResourceWatcher. The context passed to the wrapped watcher carries newObj's trace context
+// (see ExtractTraceContext); it's up to the wrapped watcher to additionally correlate oldObj's, since the two may
+// legitimately differ.
+func (w *OpinionatedWatcher) Update(ctx context.Context, oldObj, newObj resource.Object) error {
+	defer w.markInFlight(newObj)()
+	return w.Wrapped.Update(ExtractTraceContext(ctx, newObj), oldObj, newObj)
+}
+
+// Delete implements ResourceWatcher.
+func (w *OpinionatedWatcher) Delete(ctx context.Context, obj resource.Object) error {
+	defer w.markInFlight(obj)()
+	return w.Wrapped.Delete(ExtractTraceContext(ctx, obj), obj)
+}
+
+// Sync implements SyncWatcher if the wrapped watcher does.
+func (w *OpinionatedWatcher) Sync(ctx context.Context, obj resource.Object) error {
+	if sw, ok := w.Wrapped.(SyncWatcher); ok {
+		defer w.markInFlight(obj)()
+		return sw.Sync(ExtractTraceContext(ctx, obj), obj)
+	}
+	return nil
+}
+
+// Run starts the ReconcileScheduler when Config.ReconcilePeriod is non-zero and the wrapped watcher implements
+// PeriodicReconciler, and then blocks until ctx is canceled; otherwise it returns at once. Callers typically run
+// it in its own goroutine alongside the informer that delivers events to Add/Update/Delete/Sync.
+func (w *OpinionatedWatcher) Run(ctx context.Context) {
+	if w.Config.ReconcilePeriod <= 0 {
+		return
+	}
+	reconciler, ok := w.Wrapped.(PeriodicReconciler)
+	if !ok {
+		return
+	}
+	(&ReconcileScheduler{
+		Lister:          w.Lister,
+		Reconciler:      reconciler,
+		ReconcilePeriod: w.Config.ReconcilePeriod,
+		Jitter:          w.Config.ReconcileJitter,
+		Metrics:         w.Config.ReconcileMetrics,
+		Kind:            w.Config.Kind,
+		InFlight: func(obj resource.Object) bool {
+			_, inFlight := w.inFlight.Load(keyForObject("inflight", obj))
+			return inFlight
+		},
+	}).Run(ctx)
+}
+
+var (
+	_ ResourceWatcher = &OpinionatedWatcher{}
+	_ SyncWatcher     = &OpinionatedWatcher{}
+)
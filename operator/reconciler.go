@@ -0,0 +1,117 @@
+package operator
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-app-sdk/logging"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// PeriodicReconciler may optionally be implemented by a ResourceWatcher that wants to be invoked on a steady
+// cadence for every object known to the informer cache, independent of any Add/Update/Delete/Sync event.
+//
+// Sync (SyncWatcher) fires once, after an outage, to catch up on changes that were missed. Reconcile fires
+// continuously on ReconcilePeriod so a watcher can drive state toward a desired condition without depending on an
+// event at all -- a status refresh, polling an external system, or expiring a TTL.
+type PeriodicReconciler interface {
+	Reconcile(ctx context.Context, obj resource.Object) error
+}
+
+// ObjectLister is the subset of an informer's cache that ReconcileScheduler needs: every object currently known
+// for a kind.
+type ObjectLister interface {
+	ListObjects(ctx context.Context) ([]resource.Object, error)
+}
+
+// ReconcileMetrics receives counters and timings for each reconcile tick. Both methods are called synchronously
+// from the scheduler's goroutines, so implementations must be safe for concurrent use and must not block.
+type ReconcileMetrics interface {
+	// TickStarted is called once per tick, before walking the cache, with the number of objects found.
+	TickStarted(kind string, objectCount int)
+	// ObjectReconciled is called once per object after Reconcile returns, with its duration and error (nil on
+	// success).
+	ObjectReconciled(kind string, duration time.Duration, err error)
+}
+
+// ReconcileScheduler drives PeriodicReconciler.Reconcile for every object an ObjectLister knows about, once per
+// ReconcilePeriod, until Run's context is canceled.
+//
+// A tick is skipped for any object reported in-flight by InFlight, so a reconcile never races an Add/Update event
+// handler already running for the same object. The first tick is delayed by a random fraction (up to Jitter) of
+// ReconcilePeriod so that many kinds, or many replicas of the same operator, don't all tick in lockstep.
+type ReconcileScheduler struct {
+	Lister          ObjectLister
+	Reconciler      PeriodicReconciler
+	ReconcilePeriod time.Duration
+	// Jitter, between 0 and 1, is the fraction of ReconcilePeriod the first tick is randomly delayed by.
+	Jitter float64
+	// InFlight reports whether obj currently has an Add/Update/Delete being processed elsewhere; when true, the
+	// scheduler coalesces by skipping that object for the current tick. May be left nil to never skip.
+	InFlight func(obj resource.Object) bool
+	// Kind labels ticks reported to Metrics.
+	Kind    string
+	Metrics ReconcileMetrics
+}
+
+// Run walks the lister and reconciles every object once per ReconcilePeriod until ctx is canceled. It blocks
+// until ctx is done, except that it returns at once when ReconcilePeriod is not positive.
+func (s *ReconcileScheduler) Run(ctx context.Context) {
+	if s.ReconcilePeriod <= 0 {
+		return
+	}
+	if s.Jitter > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(rand.Float64() * s.Jitter * float64(s.ReconcilePeriod))):
+		}
+	}
+
+	ticker := time.NewTicker(s.ReconcilePeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *ReconcileScheduler) tick(ctx context.Context) {
+	objects, err := s.Lister.ListObjects(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("reconcile scheduler failed to list objects", "kind", s.Kind, "error", err)
+		return
+	}
+	if s.Metrics != nil {
+		s.Metrics.TickStarted(s.Kind, len(objects))
+	}
+
+	var wg sync.WaitGroup
+	for _, obj := range objects {
+		if s.InFlight != nil && s.InFlight(obj) {
+			continue
+		}
+		obj := obj
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			rErr := s.Reconciler.Reconcile(ctx, obj)
+			if s.Metrics != nil {
+				s.Metrics.ObjectReconciled(s.Kind, time.Since(start), rErr)
+			}
+			if rErr != nil {
+				meta := obj.GetStaticMetadata()
+				logging.FromContext(ctx).Error("reconcile failed", "kind", s.Kind, "name", meta.Name,
+					"namespace", meta.Namespace, "error", rErr)
+			}
+		}()
+	}
+	wg.Wait()
+}
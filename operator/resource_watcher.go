@@ -0,0 +1,23 @@
+package operator
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// ResourceWatcher is implemented by types that react to informer Add/Update/Delete events for a single kind.
+// Implementations are typically produced by the SDK's codegen from a CRD/Kind schema, but may also be
+// hand-written.
+type ResourceWatcher interface {
+	Add(ctx context.Context, obj resource.Object) error
+	Update(ctx context.Context, oldObj, newObj resource.Object) error
+	Delete(ctx context.Context, obj resource.Object) error
+}
+
+// SyncWatcher may optionally be implemented alongside ResourceWatcher. Sync is invoked once per object, after the
+// informer cache finishes its initial list, so a watcher can catch up on changes it may have missed while it
+// wasn't running.
+type SyncWatcher interface {
+	Sync(ctx context.Context, obj resource.Object) error
+}
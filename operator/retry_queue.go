@@ -0,0 +1,235 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/grafana/grafana-app-sdk/logging"
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// BackoffConfig configures the exponential backoff RetryingResourceWatcher applies to a key (one per
+// resource identifier) each time its event handler returns an error.
+type BackoffConfig struct {
+	// Base is the delay before the first retry.
+	Base time.Duration
+	// Cap is the maximum delay between retries before jitter; the exponential growth is clamped to it, and jitter
+	// may then move the delay up to Jitter*Cap above it.
+	Cap time.Duration
+	// Jitter is a fraction (0-1) of the computed delay to randomize by, in either direction, so that many keys
+	// failing at once don't all retry on the same tick.
+	Jitter float64
+	// MaxAttempts is the number of failed attempts, the first delivery included, after which an event is handed to
+	// the DeadLetterSink. A value <= 0 means retry forever.
+	MaxAttempts int
+}
+
+// DefaultBackoffConfig makes up to ten attempts, retrying after one second and doubling up to a one-minute
+// ceiling, with 20% jitter.
+var DefaultBackoffConfig = BackoffConfig{
+	Base:        time.Second,
+	Cap:         time.Minute,
+	Jitter:      0.2,
+	MaxAttempts: 10,
+}
+
+func (c BackoffConfig) delayFor(attempt int) time.Duration {
+	delay := float64(c.Base) * math.Pow(2, float64(attempt))
+	if max := float64(c.Cap); c.Cap > 0 && delay > max {
+		delay = max
+	}
+	if c.Jitter > 0 {
+		spread := delay * c.Jitter
+		delay += (rand.Float64()*2 - 1) * spread
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return time.Duration(delay)
+}
+
+// resourceKey identifies an event's target object for the purposes of per-key queuing and attempt counting.
+type resourceKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func keyForObject(kind string, obj resource.Object) resourceKey {
+	meta := obj.GetStaticMetadata()
+	return resourceKey{kind: kind, namespace: meta.Namespace, name: meta.Name}
+}
+
+type queuedEvent struct {
+	kind string
+	old  resource.Object
+	obj  resource.Object
+	seq  uint64
+}
+
+// RetryingResourceWatcher wraps a ResourceWatcher with a per-key delaying queue: when Add/Update/Delete returns an
+// error, the event is requeued with exponential backoff (BackoffConfig) instead of being dropped or immediately
+// redelivered by the informer. Each resource identifier (namespace/name/event kind) tracks its own attempt
+// counter, so a run of failures for one object does not throttle delivery for any other object.
+//
+// Once a key's attempt counter reaches BackoffConfig.MaxAttempts, or the wrapped watcher returns an error that
+// errors.Is(err, ErrTerminal), the event is handed to DeadLetterSink and dropped instead of being requeued.
+type RetryingResourceWatcher struct {
+	// Wrapped is the ResourceWatcher whose Add/Update/Delete results determine whether an event is retried.
+	Wrapped ResourceWatcher
+	// Backoff controls the retry delay and attempt budget. The zero value is invalid; use DefaultBackoffConfig.
+	Backoff BackoffConfig
+	// DeadLetterSink receives events that exhaust their retries. Defaults to a LoggingDeadLetterSink if nil.
+	DeadLetterSink DeadLetterSink
+
+	queue    workqueue.RateLimitingInterface //nolint:staticcheck // generic TypedRateLimitingInterface isn't available in all supported client-go versions
+	mux      sync.Mutex
+	events   map[resourceKey]queuedEvent
+	attempts map[resourceKey]int
+	seq      uint64
+}
+
+// NewRetryingResourceWatcher wraps watcher with a per-key retry queue using the given backoff configuration.
+func NewRetryingResourceWatcher(watcher ResourceWatcher, backoff BackoffConfig, sink DeadLetterSink) *RetryingResourceWatcher {
+	if sink == nil {
+		sink = &LoggingDeadLetterSink{}
+	}
+	return &RetryingResourceWatcher{
+		Wrapped:        watcher,
+		Backoff:        backoff,
+		DeadLetterSink: sink,
+		queue:          workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		events:         make(map[resourceKey]queuedEvent),
+		attempts:       make(map[resourceKey]int),
+	}
+}
+
+// Add implements ResourceWatcher by enqueuing the event for the underlying watcher.
+func (w *RetryingResourceWatcher) Add(_ context.Context, obj resource.Object) error {
+	w.enqueue("add", nil, obj)
+	return nil
+}
+
+// Update implements ResourceWatcher by enqueuing the event for the underlying watcher.
+func (w *RetryingResourceWatcher) Update(_ context.Context, oldObj, newObj resource.Object) error {
+	w.enqueue("update", oldObj, newObj)
+	return nil
+}
+
+// Delete implements ResourceWatcher by enqueuing the event for the underlying watcher.
+func (w *RetryingResourceWatcher) Delete(_ context.Context, obj resource.Object) error {
+	w.enqueue("delete", nil, obj)
+	return nil
+}
+
+func (w *RetryingResourceWatcher) enqueue(kind string, old, obj resource.Object) {
+	key := keyForObject(kind, obj)
+	w.mux.Lock()
+	w.seq++
+	w.events[key] = queuedEvent{kind: kind, old: old, obj: obj, seq: w.seq}
+	w.mux.Unlock()
+	w.queue.Add(key)
+}
+
+// Run processes the retry queue with the given number of concurrent workers until ctx is canceled. It blocks
+// until all workers have exited.
+func (w *RetryingResourceWatcher) Run(ctx context.Context, workers int) {
+	if workers <= 0 {
+		workers = 1
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for w.processNext(ctx) {
+			}
+		}()
+	}
+	<-ctx.Done()
+	w.queue.ShutDown()
+	wg.Wait()
+}
+
+func (w *RetryingResourceWatcher) processNext(ctx context.Context) bool {
+	item, shutdown := w.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer w.queue.Done(item)
+
+	key, ok := item.(resourceKey)
+	if !ok {
+		w.queue.Forget(item)
+		return true
+	}
+
+	w.mux.Lock()
+	event, ok := w.events[key]
+	w.mux.Unlock()
+	if !ok {
+		// The event was superseded by a newer one for the same key and already processed.
+		w.queue.Forget(item)
+		return true
+	}
+
+	err := w.dispatch(ctx, event)
+	if err == nil {
+		w.mux.Lock()
+		// Keep a newer event enqueued for the same key while this one was being processed.
+		if current, ok := w.events[key]; ok && current.seq == event.seq {
+			delete(w.events, key)
+		}
+		delete(w.attempts, key)
+		w.mux.Unlock()
+		w.queue.Forget(item)
+		return true
+	}
+
+	w.mux.Lock()
+	w.attempts[key]++
+	attempts := w.attempts[key]
+	w.mux.Unlock()
+
+	if errors.Is(err, ErrTerminal) || (w.Backoff.MaxAttempts > 0 && attempts >= w.Backoff.MaxAttempts) {
+		w.deadLetter(ctx, event, err, attempts)
+		w.mux.Lock()
+		if current, ok := w.events[key]; ok && current.seq == event.seq {
+			delete(w.events, key)
+		}
+		delete(w.attempts, key)
+		w.mux.Unlock()
+		w.queue.Forget(item)
+		return true
+	}
+
+	logging.FromContext(ctx).Info("requeuing failed event", "kind", event.kind, "attempts", attempts, "error", err)
+	w.queue.AddAfter(item, w.Backoff.delayFor(attempts-1))
+	return true
+}
+
+func (w *RetryingResourceWatcher) dispatch(ctx context.Context, event queuedEvent) error {
+	switch event.kind {
+	case "add":
+		return w.Wrapped.Add(ctx, event.obj)
+	case "update":
+		return w.Wrapped.Update(ctx, event.old, event.obj)
+	case "delete":
+		return w.Wrapped.Delete(ctx, event.obj)
+	default:
+		return fmt.Errorf("operator: unknown event kind %q: %w", event.kind, ErrTerminal)
+	}
+}
+
+func (w *RetryingResourceWatcher) deadLetter(ctx context.Context, event queuedEvent, err error, attempts int) {
+	w.DeadLetterSink.Send(ctx, event.kind, event.obj, err, attempts)
+}
+
+var _ ResourceWatcher = &RetryingResourceWatcher{}
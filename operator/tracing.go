@@ -0,0 +1,28 @@
+package operator
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// ExtractTraceContext returns ctx extended with the W3C trace context stored in obj's
+// resource.AnnotationTraceparent/AnnotationTracestate annotations (written by resource.InjectTraceContext on the
+// originating write), so a span the caller starts next becomes a child of the request that produced obj's current
+// state instead of the root of a disconnected trace. It returns ctx unchanged when obj carries no such
+// annotation. OpinionatedWatcher calls this before dispatching every Add/Update/Delete/Sync.
+func ExtractTraceContext(ctx context.Context, obj resource.Object) context.Context {
+	annotations := obj.GetAnnotations()
+	traceparent := annotations[resource.AnnotationTraceparent]
+	if traceparent == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{"traceparent": traceparent}
+	if tracestate := annotations[resource.AnnotationTracestate]; tracestate != "" {
+		carrier["tracestate"] = tracestate
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
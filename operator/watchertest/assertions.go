@@ -0,0 +1,72 @@
+package watchertest
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// AssertCalled reports whether method ("Add", "Update", "Delete", "Sync", or "Reconcile") was invoked for the
+// object named name at any point during the fixture's script so far.
+func (f *Fixture) AssertCalled(method, name string) bool {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	for _, c := range f.calls {
+		if c.method == method && c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertNoErrors returns the first error any scripted call returned, or nil if every call so far succeeded.
+func (f *Fixture) AssertNoErrors() error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	for _, c := range f.calls {
+		if c.err != nil {
+			return fmt.Errorf("%s(%s): %w", c.method, c.name, c.err)
+		}
+	}
+	return nil
+}
+
+// SpanAssertion is a small chainable helper over spans recorded by a Fixture's in-memory exporter.
+type SpanAssertion struct {
+	spans tracetest.SpanStubs
+}
+
+// Spans returns every span the fixture's in-memory exporter has recorded so far.
+func (f *Fixture) Spans() SpanAssertion {
+	return SpanAssertion{spans: f.exporter.GetSpans()}
+}
+
+// AssertSpan returns a SpanAssertion scoped to spans named name (e.g. "watcher-add"), for chaining further
+// attribute checks.
+func (f *Fixture) AssertSpan(name string) SpanAssertion {
+	var matched tracetest.SpanStubs
+	for _, s := range f.exporter.GetSpans() {
+		if s.Name == name {
+			matched = append(matched, s)
+		}
+	}
+	return SpanAssertion{spans: matched}
+}
+
+// Exists reports whether the assertion matched at least one span.
+func (a SpanAssertion) Exists() bool {
+	return len(a.spans) > 0
+}
+
+// HasAttribute reports whether any matched span carries an attribute named key whose value, compared via
+// fmt.Sprint, equals value -- so callers don't need to construct an attribute.KeyValue themselves.
+func (a SpanAssertion) HasAttribute(key string, value any) bool {
+	for _, s := range a.spans {
+		for _, attr := range s.Attributes {
+			if string(attr.Key) == key && fmt.Sprint(attr.Value.AsInterface()) == fmt.Sprint(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package watchertest
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/grafana/grafana-app-sdk/resource"
+)
+
+// Script is a declarative, YAML-loadable sequence of events for Fixture.RunScript, so a table-driven test can
+// express a scenario as data instead of a sequence of Go calls.
+type Script struct {
+	Steps []ScriptStep `json:"steps"`
+}
+
+// ScriptStep is one entry in a Script. Exactly one field should be set.
+type ScriptStep struct {
+	Add         *ScriptObject  `json:"add,omitempty"`
+	Update      *ScriptUpdate  `json:"update,omitempty"`
+	Delete      *ScriptObject  `json:"delete,omitempty"`
+	Sync        *ScriptObject  `json:"sync,omitempty"`
+	AdvanceTime *ScriptAdvance `json:"advanceTime,omitempty"`
+}
+
+// ScriptObject is a step's raw object body; Fixture.RunScript passes it to a Decoder to get a resource.Object.
+type ScriptObject map[string]any
+
+// ScriptUpdate pairs the old and new bodies of an update step.
+type ScriptUpdate struct {
+	Old ScriptObject `json:"old"`
+	New ScriptObject `json:"new"`
+}
+
+// ScriptAdvance is the body of an advanceTime step.
+type ScriptAdvance struct {
+	Duration string         `json:"duration"`
+	Objects  []ScriptObject `json:"objects,omitempty"`
+}
+
+// Decoder turns a ScriptStep's raw object body into the concrete resource.Object type a test's watcher expects,
+// since Script itself has no way to know which generated type (e.g. *issuev1.Issue) a YAML body decodes to.
+type Decoder func(ScriptObject) (resource.Object, error)
+
+// LoadScript parses YAML-encoded Script data, typically read from a table-driven test's testdata directory.
+func LoadScript(data []byte) (Script, error) {
+	var s Script
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return Script{}, fmt.Errorf("decoding watchertest script: %w", err)
+	}
+	return s, nil
+}
+
+// RunScript drives every step of script into the fixture in order, using decode to turn each step's raw object
+// body into a resource.Object. It stops and returns the first error a step produces, whether from decode or from
+// the watcher call itself.
+func (f *Fixture) RunScript(script Script, decode Decoder) error {
+	for i, step := range script.Steps {
+		if err := f.runStep(step, decode); err != nil {
+			return fmt.Errorf("script step %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (f *Fixture) runStep(step ScriptStep, decode Decoder) error {
+	switch {
+	case step.Add != nil:
+		obj, err := decode(*step.Add)
+		if err != nil {
+			return err
+		}
+		return f.Add(obj)
+	case step.Update != nil:
+		oldObj, err := decode(step.Update.Old)
+		if err != nil {
+			return err
+		}
+		newObj, err := decode(step.Update.New)
+		if err != nil {
+			return err
+		}
+		return f.Update(oldObj, newObj)
+	case step.Delete != nil:
+		obj, err := decode(*step.Delete)
+		if err != nil {
+			return err
+		}
+		return f.Delete(obj)
+	case step.Sync != nil:
+		obj, err := decode(*step.Sync)
+		if err != nil {
+			return err
+		}
+		return f.Sync(obj)
+	case step.AdvanceTime != nil:
+		d, err := time.ParseDuration(step.AdvanceTime.Duration)
+		if err != nil {
+			return fmt.Errorf("parsing advanceTime duration: %w", err)
+		}
+		objects := make([]resource.Object, 0, len(step.AdvanceTime.Objects))
+		for _, raw := range step.AdvanceTime.Objects {
+			obj, err := decode(raw)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, obj)
+		}
+		return f.AdvanceTime(d, objects...)
+	default:
+		return fmt.Errorf("script step has no recognized action")
+	}
+}
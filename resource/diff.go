@@ -0,0 +1,140 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+)
+
+// AnnotationLastAppliedConfig is the annotation key SetLastAppliedAnnotation stores an Object's own JSON encoding
+// under, mirroring kubectl apply's kubectl.kubernetes.io/last-applied-configuration annotation, scoped to this
+// SDK's own write path so ThreeWayDiff has a record of what a user actually submitted.
+const AnnotationLastAppliedConfig = "grafana.app/last-applied-configuration"
+
+// sdkManagedAnnotations are annotation keys this package (or resource.InjectTraceContext) itself writes on every
+// Create/Update. They change on essentially every write regardless of whether the user changed anything, so they
+// are stripped from all three inputs to the merge patch -- otherwise diff.Changed("metadata") would be true for
+// almost every Update and the field-level dispatch ThreeWayDiff exists to support would be useless for anything
+// but "spec"/"status".
+var sdkManagedAnnotations = []string{
+	AnnotationLastAppliedConfig,
+	AnnotationTraceparent,
+	AnnotationTracestate,
+}
+
+// Patch is a JSON merge patch, as produced by ThreeWayDiff.
+type Patch []byte
+
+// Diff reports which top-level fields of a Kind's schema changed, as computed by ThreeWayDiff.
+type Diff struct {
+	changed map[string]struct{}
+}
+
+// NewDiff wraps a set of changed top-level field names (e.g. "spec", "status") in a Diff.
+func NewDiff(changedFields []string) Diff {
+	d := Diff{changed: make(map[string]struct{}, len(changedFields))}
+	for _, f := range changedFields {
+		d.changed[f] = struct{}{}
+	}
+	return d
+}
+
+// Changed reports whether field (a top-level field name such as "spec" or "status") was part of the diff.
+func (d Diff) Changed(field string) bool {
+	_, ok := d.changed[field]
+	return ok
+}
+
+// ChangedFields returns every top-level field name Changed would report true for.
+func (d Diff) ChangedFields() []string {
+	fields := make([]string, 0, len(d.changed))
+	for f := range d.changed {
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// ThreeWayDiff computes a three-way JSON merge patch the way `kubectl apply` does, with old as the current state,
+// new as the modified state and lastApplied as the original, and returns it with the top-level field names it
+// touches. Added and changed fields are taken from old against new, so a change made between old and new by the
+// server or another controller (a status subresource write, defaulting) is reported just like a user's edit;
+// lastApplied only decides removals: a field present in lastApplied and absent from new is reported as removed.
+// lastApplied is the JSON value previously stored by SetLastAppliedAnnotation, typically read from
+// old.GetAnnotations()[AnnotationLastAppliedConfig]; when empty (the object predates this annotation, or was
+// never written through this SDK's client) ThreeWayDiff falls back to a plain two-way diff of old against new.
+func ThreeWayDiff(old, new Object, lastApplied string) (Patch, []string, error) { //nolint:revive // "new" mirrors the Update(rOld, rNew) naming generated watchers use
+	oldJSON, err := json.Marshal(old)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling old object: %w", err)
+	}
+	newJSON, err := json.Marshal(new)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling new object: %w", err)
+	}
+
+	original := oldJSON
+	if lastApplied != "" {
+		original = []byte(lastApplied)
+	}
+
+	original, err = stripSDKManagedAnnotations(original)
+	if err != nil {
+		return nil, nil, fmt.Errorf("stripping SDK-managed annotations from last-applied configuration: %w", err)
+	}
+	modified, err := stripSDKManagedAnnotations(newJSON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("stripping SDK-managed annotations from new object: %w", err)
+	}
+	current, err := stripSDKManagedAnnotations(oldJSON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("stripping SDK-managed annotations from old object: %w", err)
+	}
+
+	patchBytes, err := jsonmergepatch.CreateThreeWayJSONMergePatch(original, modified, current)
+	if err != nil {
+		return nil, nil, fmt.Errorf("computing three-way merge patch: %w", err)
+	}
+
+	var patchMap map[string]any
+	if err := json.Unmarshal(patchBytes, &patchMap); err != nil {
+		return nil, nil, fmt.Errorf("decoding merge patch: %w", err)
+	}
+	fields := make([]string, 0, len(patchMap))
+	for f := range patchMap {
+		fields = append(fields, f)
+	}
+	return Patch(patchBytes), fields, nil
+}
+
+// stripSDKManagedAnnotations returns raw with sdkManagedAnnotations removed from metadata.annotations, so they
+// don't show up as changes to a caller that only cares about what the user edited. raw is returned unmodified if
+// it doesn't decode to an object with a metadata.annotations map.
+func stripSDKManagedAnnotations(raw []byte) ([]byte, error) {
+	var obj map[string]any
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	metadata, ok := obj["metadata"].(map[string]any)
+	if !ok {
+		return raw, nil
+	}
+	annotations, ok := metadata["annotations"].(map[string]any)
+	if !ok {
+		return raw, nil
+	}
+
+	stripped := false
+	for _, key := range sdkManagedAnnotations {
+		if _, present := annotations[key]; present {
+			delete(annotations, key)
+			stripped = true
+		}
+	}
+	if !stripped {
+		return raw, nil
+	}
+
+	return json.Marshal(obj)
+}
@@ -0,0 +1,25 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SetLastAppliedAnnotation stamps obj's AnnotationLastAppliedConfig annotation with obj's own current JSON
+// encoding. The SDK's generated and REST clients call this on every Create and Update so that a later
+// ThreeWayDiff has a record of what the user actually submitted, distinct from whatever the API server, a
+// mutating webhook, or another controller writes to the object afterward.
+func SetLastAppliedAnnotation(obj Object) error {
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("marshaling object for last-applied annotation: %w", err)
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[AnnotationLastAppliedConfig] = string(encoded)
+	obj.SetAnnotations(annotations)
+	return nil
+}
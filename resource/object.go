@@ -0,0 +1,41 @@
+package resource
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ObjectIdentifier uniquely identifies an Object within its kind.
+type ObjectIdentifier struct {
+	Name      string
+	Namespace string
+}
+
+// StaticMetadata holds the identifying fields of an Object that never change after creation.
+type StaticMetadata struct {
+	Name      string
+	Namespace string
+	Kind      string
+	Group     string
+	Version   string
+}
+
+// Identifier returns the subset of StaticMetadata that uniquely identifies the object within its kind.
+func (m StaticMetadata) Identifier() ObjectIdentifier {
+	return ObjectIdentifier{Name: m.Name, Namespace: m.Namespace}
+}
+
+// Object is the interface satisfied by every typed and untyped Kind representation the SDK generates. It extends
+// runtime.Object so SDK objects can be used directly with client-go and controller-runtime machinery (informers,
+// event recorders, dynamic clients).
+type Object interface {
+	runtime.Object
+	GetStaticMetadata() StaticMetadata
+	GetAnnotations() map[string]string
+	SetAnnotations(map[string]string)
+	GetLabels() map[string]string
+}
+
+// SpecObject is implemented by typed Objects that expose their spec for generic tooling.
+type SpecObject interface {
+	GetSpec() any
+}
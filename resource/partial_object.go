@@ -0,0 +1,29 @@
+package resource
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// PartialObject is the lightweight counterpart to Object: TypeMeta, ObjectMeta (so identity, labels, annotations
+// and OwnerReferences are all available) but none of the kind-specific spec/status. It is what a MetadataWatcher
+// receives instead of a fully-deserialized Object, and is populated from the Kubernetes
+// PartialObjectMetadata REST representation (the `?includeObject=Metadata` list/watch semantics used by
+// client-go's metadata.Interface) rather than from a typed or unstructured decode of the whole resource.
+type PartialObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// DeepCopyObject implements runtime.Object.
+func (p *PartialObject) DeepCopyObject() runtime.Object {
+	if p == nil {
+		return nil
+	}
+	out := new(PartialObject)
+	out.TypeMeta = p.TypeMeta
+	p.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return out
+}
+
+var _ runtime.Object = &PartialObject{}
@@ -0,0 +1,64 @@
+package resource
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// AnnotationTraceparent stores the W3C traceparent of the request that produced the object's current write,
+	// so a later watcher event for that write can resume the same distributed trace instead of starting a new,
+	// disconnected one.
+	AnnotationTraceparent = "grafana.app/traceparent"
+	// AnnotationTracestate stores the accompanying W3C tracestate, when the propagator carries one.
+	AnnotationTracestate = "grafana.app/tracestate"
+)
+
+// TracePropagationOptions configures InjectTraceContext.
+type TracePropagationOptions struct {
+	enabled bool
+}
+
+// TracePropagationOption configures a TracePropagationOptions.
+type TracePropagationOption func(*TracePropagationOptions)
+
+// WithTracePropagation opts a single InjectTraceContext call in or out of writing the traceparent/tracestate
+// annotations. Propagation is on by default, so this is typically used as WithTracePropagation(false) -- for
+// example for a bulk migration write, where every object would otherwise link back to the same originating span.
+func WithTracePropagation(enabled bool) TracePropagationOption {
+	return func(o *TracePropagationOptions) { o.enabled = enabled }
+}
+
+// InjectTraceContext writes the W3C traceparent (and tracestate, if the configured propagator carries one) of the
+// span in ctx into obj's AnnotationTraceparent/AnnotationTracestate annotations. The SDK's generated and REST
+// clients call this on every Create and Update, alongside SetLastAppliedAnnotation, so that the operator's event
+// dispatcher can later extract it (see operator.ExtractTraceContext) and make the watcher's span a child of the
+// request that caused the write, instead of the root of a disconnected trace.
+func InjectTraceContext(ctx context.Context, obj Object, opts ...TracePropagationOption) {
+	options := TracePropagationOptions{enabled: true}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if !options.enabled {
+		return
+	}
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	traceparent, ok := carrier["traceparent"]
+	if !ok {
+		return
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, len(carrier))
+	}
+	annotations[AnnotationTraceparent] = traceparent
+	if tracestate, ok := carrier["tracestate"]; ok {
+		annotations[AnnotationTracestate] = tracestate
+	}
+	obj.SetAnnotations(annotations)
+}